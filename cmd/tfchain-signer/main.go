@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/jimbersoftware/tfchain/pkg/config"
+
+	"github.com/bgentry/speakeasy"
+	"github.com/jimbersoftware/rivine/modules"
+	"github.com/jimbersoftware/rivine/modules/signer"
+	"github.com/jimbersoftware/rivine/persist"
+	"github.com/jimbersoftware/rivine/types"
+	"github.com/spf13/cobra"
+)
+
+// mnemonicEnvVar is the environment variable from which the mnemonic is read,
+// if defined, instead of prompting for it.
+const mnemonicEnvVar = "TFCHAIN_SIGNER_MNEMONIC"
+
+// signerConfig is the configuration of the signer,
+// as given by the command line flags.
+type signerConfig struct {
+	Socket   string
+	Keys     uint64
+	Delegate bool
+}
+
+func main() {
+	cfg := signerConfig{
+		Socket: "tfchain-signer.sock",
+		Keys:   1,
+	}
+	root := &cobra.Command{
+		Use:   os.Args[0],
+		Short: "Tfchain block stake signer",
+		Long: `Holds the block stake keys of a block creator, derived from a wallet mnemonic,
+and signs the block stake respends requested by a tfchaind daemon started with --signer.
+
+Only transactions that respend a single block stake output to the same value and condition,
+without any coin inputs or outputs, are signed. The mnemonic is read from the ` + mnemonicEnvVar + `
+environment variable, or prompted for if it isn't defined.`,
+		Example: os.Args[0] + " --socket /run/tfchain/signer.sock --keys 5",
+		Args:    cobra.NoArgs,
+		RunE: func(*cobra.Command, []string) error {
+			return runSigner(cfg)
+		},
+	}
+	root.Flags().StringVarP(&cfg.Socket, "socket", "s", cfg.Socket, "the unix socket on which the signer listens")
+	root.Flags().Uint64VarP(&cfg.Keys, "keys", "k", cfg.Keys, "the amount of keys derived from the mnemonic")
+	root.Flags().BoolVarP(&cfg.Delegate, "delegate", "", cfg.Delegate,
+		"also sign respends of block stakes delegated to or by the keys, only safe if the keys hold no block stakes themselves")
+
+	if err := root.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// runSigner derives the keys and serves the signer on the configured socket,
+// until the process is interrupted.
+func runSigner(cfg signerConfig) error {
+	if cfg.Keys == 0 {
+		return errors.New("at least one key has to be derived")
+	}
+	mnemonic := os.Getenv(mnemonicEnvVar)
+	if mnemonic == "" {
+		var err error
+		mnemonic, err = speakeasy.Ask("Mnemonic: ")
+		if err != nil {
+			return err
+		}
+	}
+	seed, err := modules.InitialSeedFromMnemonic(strings.TrimSpace(mnemonic))
+	if err != nil {
+		return fmt.Errorf("invalid mnemonic: %v", err)
+	}
+	keys := signer.KeysFromSeed(seed, cfg.Keys)
+	for _, key := range keys {
+		fmt.Println("Signing for", types.NewEd25519PubKeyUnlockHash(key.PublicKey))
+	}
+
+	// remove a socket left behind by a previous run, and only allow the owner to use it
+	if err = os.Remove(cfg.Socket); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	l, err := net.Listen("unix", cfg.Socket)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	if err = os.Chmod(cfg.Socket, 0600); err != nil {
+		return err
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		fmt.Println("\rCaught stop signal, quitting...")
+		l.Close()
+	}()
+
+	log := persist.NewLogger(config.GetBlockchainInfo(), os.Stdout)
+	fmt.Println("Listening on", cfg.Socket)
+	err = signer.NewServer(keys, signer.Policy{Delegate: cfg.Delegate}, log).Serve(l)
+	if opErr, ok := err.(*net.OpError); ok && opErr.Op == "accept" {
+		// the listener was closed to quit
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/jimbersoftware/tfchain/pkg/config"
+
+	"github.com/jimbersoftware/rivine/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	devnet      = "devnet"
+	testnet     = "testnet"
+	standardnet = "standard"
+)
+
+// simConfig is the configuration of a simulation,
+// as given by the command line flags.
+type simConfig struct {
+	NetworkName string
+	Blocks      uint64
+	Seed        int64
+	Stakers     []string
+
+	BlockFrequency     uint64
+	TargetWindow       uint64
+	MaxAdjustmentUp    string
+	MaxAdjustmentDown  string
+	StakeModifierDelay uint64
+	BlockStakeAging    uint64
+	FutureThreshold    uint64
+}
+
+func main() {
+	cfg := simConfig{
+		NetworkName: standardnet,
+		Blocks:      5000,
+		Seed:        1,
+	}
+	root := &cobra.Command{
+		Use:   os.Args[0],
+		Short: "Tfchain proof of block stake simulator",
+		Long: `Simulates the creation of blocks by a population of stakers, using the proof of block stake rules
+of the chosen network, optionally overwriting the parameters that affect the creation of blocks.
+
+Stakers are given as <blockstakes>[:<behaviour>[:<uptime>]], where behaviour is either
+"honest" (default) or "adversarial", and uptime the fraction of blocks (default 1) for which
+the staker is online. Honest stakers create blocks timestamped at the time they are created,
+while adversarial stakers try every timestamp accepted by consensus, ranging from the minimum
+valid timestamp to the future threshold, to create blocks as early as possible.
+If no stakers are given, one honest staker is simulated for each genesis block stake allocation.`,
+		Example: os.Args[0] + " --staker 100 --staker 50:honest:0.5 --staker 10:adversarial --target-window 200",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			chainCts, err := networkConstants(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			stakers, err := parseStakers(cfg.Stakers, chainCts)
+			if err != nil {
+				return err
+			}
+			// the start difficulty follows from the block stakes in the genesis block
+			chainCts.GenesisBlockStakeAllocation = make([]types.BlockStakeOutput, len(stakers))
+			for i, s := range stakers {
+				chainCts.GenesisBlockStakeAllocation[i].Value = s.Stakes
+			}
+			sim := newSimulation(chainCts, stakers, cfg.Seed)
+			if err := sim.Run(types.BlockHeight(cfg.Blocks)); err != nil {
+				return err
+			}
+			sim.Report(os.Stdout, cfg.NetworkName)
+			return nil
+		},
+	}
+	root.Flags().StringVarP(&cfg.NetworkName, "network", "n", cfg.NetworkName, "the name of the network of which the parameters are simulated")
+	root.Flags().Uint64VarP(&cfg.Blocks, "blocks", "b", cfg.Blocks, "the amount of blocks to simulate")
+	root.Flags().Int64VarP(&cfg.Seed, "seed", "", cfg.Seed, "the seed of the random generator, as to reproduce a simulation")
+	root.Flags().StringArrayVarP(&cfg.Stakers, "staker", "s", nil, "a staker to simulate, as <blockstakes>[:<behaviour>[:<uptime>]], can be repeated")
+	root.Flags().Uint64VarP(&cfg.BlockFrequency, "block-frequency", "", 0, "overwrite the target amount of seconds between blocks")
+	root.Flags().Uint64VarP(&cfg.TargetWindow, "target-window", "", 0, "overwrite the amount of blocks used to adjust the difficulty")
+	root.Flags().StringVarP(&cfg.MaxAdjustmentUp, "max-adjustment-up", "", "", "overwrite the maximum difficulty adjustment up, as a fraction such as 25/10")
+	root.Flags().StringVarP(&cfg.MaxAdjustmentDown, "max-adjustment-down", "", "", "overwrite the maximum difficulty adjustment down, as a fraction such as 10/25")
+	root.Flags().Uint64VarP(&cfg.StakeModifierDelay, "stake-modifier-delay", "", 0, "overwrite the amount of blocks the stake modifier is delayed by")
+	root.Flags().Uint64VarP(&cfg.BlockStakeAging, "block-stake-aging", "", 0, "overwrite the amount of seconds before transferred block stakes can create blocks")
+	root.Flags().Uint64VarP(&cfg.FutureThreshold, "future-threshold", "", 0, "overwrite the amount of seconds a block timestamp can be in the future")
+
+	if err := root.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// networkConstants returns the chain constants of the configured network,
+// with the parameters given as flags overwritten.
+func networkConstants(cmd *cobra.Command, cfg simConfig) (types.ChainConstants, error) {
+	var chainCts types.ChainConstants
+	switch cfg.NetworkName {
+	case standardnet:
+		chainCts = config.GetStandardnetGenesis()
+	case testnet:
+		chainCts = config.GetTestnetGenesis()
+	case devnet:
+		chainCts = config.GetDevnetGenesis()
+	default:
+		return types.ChainConstants{}, fmt.Errorf("Network name %q not recognized", cfg.NetworkName)
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("block-frequency") {
+		chainCts.BlockFrequency = types.BlockHeight(cfg.BlockFrequency)
+	}
+	if flags.Changed("target-window") {
+		chainCts.TargetWindow = types.BlockHeight(cfg.TargetWindow)
+	}
+	if flags.Changed("max-adjustment-up") {
+		r, ok := new(big.Rat).SetString(cfg.MaxAdjustmentUp)
+		if !ok {
+			return types.ChainConstants{}, fmt.Errorf("invalid max adjustment up %q", cfg.MaxAdjustmentUp)
+		}
+		chainCts.MaxAdjustmentUp = r
+	}
+	if flags.Changed("max-adjustment-down") {
+		r, ok := new(big.Rat).SetString(cfg.MaxAdjustmentDown)
+		if !ok {
+			return types.ChainConstants{}, fmt.Errorf("invalid max adjustment down %q", cfg.MaxAdjustmentDown)
+		}
+		chainCts.MaxAdjustmentDown = r
+	}
+	if flags.Changed("stake-modifier-delay") {
+		chainCts.StakeModifierDelay = types.BlockHeight(cfg.StakeModifierDelay)
+	}
+	if flags.Changed("block-stake-aging") {
+		chainCts.BlockStakeAging = cfg.BlockStakeAging
+	}
+	if flags.Changed("future-threshold") {
+		chainCts.FutureThreshold = types.Timestamp(cfg.FutureThreshold)
+	}
+
+	if chainCts.BlockFrequency == 0 {
+		return types.ChainConstants{}, fmt.Errorf("the block frequency has to be positive")
+	}
+	if chainCts.TargetWindow < 2 {
+		return types.ChainConstants{}, fmt.Errorf("the target window has to be at least 2 blocks")
+	}
+	if chainCts.StakeModifierDelay == 0 {
+		return types.ChainConstants{}, fmt.Errorf("the stake modifier delay has to be positive")
+	}
+	if chainCts.MaxAdjustmentUp.Cmp(big.NewRat(1, 1)) < 0 || chainCts.MaxAdjustmentDown.Cmp(big.NewRat(1, 1)) > 0 ||
+		chainCts.MaxAdjustmentDown.Sign() <= 0 {
+		return types.ChainConstants{}, fmt.Errorf("the max adjustment up has to be at least 1, and the max adjustment down positive and at most 1")
+	}
+	return chainCts, nil
+}
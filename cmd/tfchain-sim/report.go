@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jimbersoftware/rivine/modules/consensus"
+	"github.com/jimbersoftware/rivine/types"
+)
+
+const (
+	// maxDifficultyRows is the maximum amount of difficulty adjustments listed in the report.
+	maxDifficultyRows = 20
+	// histogramBuckets is the amount of block time buckets, each half the block frequency wide,
+	// the last of which contains all longer block times.
+	histogramBuckets = 8
+	// histogramWidth is the width of the largest histogram bar.
+	histogramWidth = 50
+)
+
+// Report writes the block time distribution, the convergence of the difficulty
+// and the share of the blocks created by each staker of the simulation.
+func (s *simulation) Report(w io.Writer, networkName string) {
+	cts := s.chainCts
+	fmt.Fprintf(w, "Simulated %d blocks on the %s network\n", len(s.blocks)-1, networkName)
+	fmt.Fprintf(w, "Block frequency: %ds, target window: %d blocks, max adjustment: %s up, %s down\n",
+		cts.BlockFrequency, cts.TargetWindow, cts.MaxAdjustmentUp.FloatString(2), cts.MaxAdjustmentDown.FloatString(2))
+	fmt.Fprintf(w, "Stake modifier delay: %d blocks, block stake aging: %ds, future threshold: %ds\n",
+		cts.StakeModifierDelay, cts.BlockStakeAging, cts.FutureThreshold)
+
+	s.reportBlockTimes(w)
+	s.reportDifficulty(w)
+	s.reportStakers(w)
+}
+
+// reportBlockTimes writes the distribution of the time between blocks, according to the fake clock.
+func (s *simulation) reportBlockTimes(w io.Writer) {
+	times := make([]float64, 0, len(s.blocks)-1)
+	var sum, maxAhead, maxBehind float64
+	for i := 1; i < len(s.blocks); i++ {
+		t := float64(s.blocks[i].Created) - float64(s.blocks[i-1].Created)
+		times = append(times, t)
+		sum += t
+		drift := float64(s.blocks[i].Timestamp) - float64(s.blocks[i].Created)
+		maxAhead = math.Max(maxAhead, drift)
+		maxBehind = math.Max(maxBehind, -drift)
+	}
+	if len(times) == 0 {
+		return
+	}
+	mean := sum / float64(len(times))
+	var variance float64
+	for _, t := range times {
+		variance += (t - mean) * (t - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(times)))
+	sort.Float64s(times)
+	percentile := func(p float64) float64 {
+		return times[int(p*float64(len(times)-1))]
+	}
+
+	fmt.Fprintln(w, "\nBlock times:")
+	fmt.Fprintf(w, "  mean %.1fs, stddev %.1fs, min %.0fs, p10 %.0fs, p50 %.0fs, p90 %.0fs, p99 %.0fs, max %.0fs\n",
+		mean, stddev, times[0], percentile(0.1), percentile(0.5), percentile(0.9), percentile(0.99), times[len(times)-1])
+	fmt.Fprintf(w, "  timestamps up to %.0fs ahead of and %.0fs behind the time blocks were created\n", maxAhead, maxBehind)
+
+	bucketSize := float64(s.chainCts.BlockFrequency) / 2
+	var buckets [histogramBuckets]int
+	var largest int
+	for _, t := range times {
+		i := int(t / bucketSize)
+		if i >= histogramBuckets {
+			i = histogramBuckets - 1
+		}
+		buckets[i]++
+		if buckets[i] > largest {
+			largest = buckets[i]
+		}
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, count := range buckets {
+		label := fmt.Sprintf("%.0f-%.0fs", float64(i)*bucketSize, float64(i+1)*bucketSize)
+		if i == histogramBuckets-1 {
+			label = fmt.Sprintf("%.0fs+", float64(i)*bucketSize)
+		}
+		bar := strings.Repeat("#", count*histogramWidth/largest)
+		fmt.Fprintf(tw, "  %s\t%d\t%.1f%%\t%s\n", label, count, percentage(uint64(count), uint64(len(times))), bar)
+	}
+	tw.Flush()
+}
+
+// reportDifficulty writes the difficulty, relative to the start difficulty, after the adjustments of
+// the difficulty, together with the mean block time of the blocks preceding each adjustment.
+func (s *simulation) reportDifficulty(w io.Writer) {
+	var heights []types.BlockHeight
+	for height := types.BlockHeight(1); height < types.BlockHeight(len(s.blocks)); height++ {
+		if consensus.IsTargetAdjustmentHeight(s.chainCts, height) {
+			heights = append(heights, height)
+		}
+	}
+	if len(heights) == 0 {
+		fmt.Fprintln(w, "\nNo difficulty adjustments were made.")
+		return
+	}
+	step := (len(heights) + maxDifficultyRows - 1) / maxDifficultyRows
+
+	fmt.Fprintln(w, "\nDifficulty adjustments:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  Height\tDifficulty\tMean block time")
+	for i := step - 1; i < len(heights); i += step {
+		height := heights[i]
+		interval := s.chainCts.TargetWindow / 2
+		fmt.Fprintf(tw, "  %d\t%.3fx\t%.1fs\n", height, s.relativeDifficulty(s.blocks[height].ChildTarget), s.meanBlockTime(height, interval))
+	}
+	tw.Flush()
+
+	window := s.chainCts.TargetWindow
+	tip := types.BlockHeight(len(s.blocks) - 1)
+	if window > tip {
+		window = tip
+	}
+	fmt.Fprintf(w, "  mean block time of the last %d blocks: %.1fs, targeting %ds\n",
+		window, s.meanBlockTime(tip, window), s.chainCts.BlockFrequency)
+}
+
+// reportStakers writes the share of the block stakes and of the blocks created of each staker.
+func (s *simulation) reportStakers(w io.Writer) {
+	total := s.chainCts.GenesisBlockStakeCount()
+	blocks := uint64(len(s.blocks) - 1)
+
+	fmt.Fprintln(w, "\nStakers:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  #\tBehaviour\tUptime\tBlock stakes\tStake share\tBlocks\tBlock share")
+	for i, st := range s.stakers {
+		stakeShare, _ := new(big.Rat).SetFrac(st.Stakes.Big(), total.Big()).Float64()
+		fmt.Fprintf(tw, "  %d\t%v\t%.0f%%\t%v\t%.2f%%\t%d\t%.2f%%\n", i, st.Behaviour, st.Uptime*100,
+			st.Stakes, stakeShare*100, st.blocks, percentage(st.blocks, blocks))
+	}
+	tw.Flush()
+}
+
+// relativeDifficulty returns the difficulty of the given target, relative to the start difficulty.
+func (s *simulation) relativeDifficulty(target types.Target) float64 {
+	if target == (types.Target{}) {
+		return math.Inf(1)
+	}
+	f, _ := new(big.Rat).SetFrac(s.chainCts.RootTarget().Int(), target.Int()).Float64()
+	return f
+}
+
+// meanBlockTime returns the mean time between the given amount of blocks up to the given height,
+// according to the fake clock.
+func (s *simulation) meanBlockTime(height, blocks types.BlockHeight) float64 {
+	if blocks == 0 {
+		return 0
+	}
+	return float64(s.blocks[height].Created-s.blocks[height-blocks].Created) / float64(blocks)
+}
+
+// percentage returns n as a percentage of total.
+func percentage(n, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jimbersoftware/rivine/crypto"
+	"github.com/jimbersoftware/rivine/modules/blockcreator"
+	"github.com/jimbersoftware/rivine/modules/consensus"
+	"github.com/jimbersoftware/rivine/types"
+)
+
+// behaviour defines how a staker creates blocks.
+type behaviour int
+
+const (
+	// honest stakers create blocks timestamped at the time they are created,
+	// as the block creator module does.
+	honest behaviour = iota
+	// adversarial stakers try all timestamps accepted by consensus,
+	// as to create blocks as early as possible.
+	adversarial
+)
+
+// maxIdleBlocks is the amount of blocks, expressed in time using the block frequency,
+// after which the simulation is aborted if no block is created.
+const maxIdleBlocks = 1000
+
+var errNoBlockCreated = errors.New("no block was created, as not enough block stakes are online")
+
+func (b behaviour) String() string {
+	if b == adversarial {
+		return "adversarial"
+	}
+	return "honest"
+}
+
+// staker is a simulated block stake holder, which uses a single
+// block stake output to create blocks.
+type staker struct {
+	Stakes    types.Currency
+	Behaviour behaviour
+	Uptime    float64
+
+	// output is the block stake output used to create blocks, which is respent as the
+	// first output of the first transaction of each block created, as done by the block creator.
+	output types.BlockStakeOutputIndexes
+	// eligible is the timestamp from which the output has aged enough to create blocks.
+	eligible types.Timestamp
+	blocks   uint64
+}
+
+// simBlock is a block created in the simulation.
+type simBlock struct {
+	ID        types.BlockID
+	Timestamp types.Timestamp
+	// Created is the time, according to the fake clock, at which the block was created.
+	Created     types.Timestamp
+	ChildTarget types.Target
+	// Creator is the index of the staker that created the block, -1 for the genesis block.
+	Creator int
+}
+
+// simulation simulates the creation of blocks by a population of stakers,
+// using a fake clock that starts at the genesis timestamp.
+type simulation struct {
+	chainCts types.ChainConstants
+	stakers  []*staker
+	rand     *rand.Rand
+	// blocks are the blocks of the simulated chain, indexed by height.
+	blocks []simBlock
+}
+
+// parseStakers parses the stakers given as <blockstakes>[:<behaviour>[:<uptime>]].
+// If no stakers are given, an honest staker is returned for each genesis block stake allocation.
+func parseStakers(specs []string, chainCts types.ChainConstants) ([]*staker, error) {
+	var stakers []*staker
+	if len(specs) == 0 {
+		for _, bso := range chainCts.GenesisBlockStakeAllocation {
+			stakers = append(stakers, &staker{Stakes: bso.Value, Uptime: 1})
+		}
+	}
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid staker %q", spec)
+		}
+		stakes, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil || stakes == 0 {
+			return nil, fmt.Errorf("invalid block stakes of staker %q", spec)
+		}
+		s := &staker{Stakes: types.NewCurrency64(stakes), Uptime: 1}
+		if len(parts) > 1 {
+			switch parts[1] {
+			case "honest":
+				s.Behaviour = honest
+			case "adversarial":
+				s.Behaviour = adversarial
+			default:
+				return nil, fmt.Errorf("invalid behaviour of staker %q", spec)
+			}
+		}
+		if len(parts) > 2 {
+			s.Uptime, err = strconv.ParseFloat(parts[2], 64)
+			if err != nil || s.Uptime < 0 || s.Uptime > 1 {
+				return nil, fmt.Errorf("invalid uptime of staker %q, has to be between 0 and 1", spec)
+			}
+		}
+		stakers = append(stakers, s)
+	}
+	if len(stakers) == 0 {
+		return nil, errors.New("no stakers to simulate")
+	}
+	return stakers, nil
+}
+
+// newSimulation creates a simulation starting from the genesis block,
+// in which each staker holds one of the genesis block stake outputs.
+func newSimulation(chainCts types.ChainConstants, stakers []*staker, seed int64) *simulation {
+	for i, s := range stakers {
+		s.output = types.BlockStakeOutputIndexes{OutputIndex: uint64(i)}
+		if i != 0 {
+			s.eligible = chainCts.GenesisTimestamp + types.Timestamp(chainCts.BlockStakeAging)
+		}
+	}
+	return &simulation{
+		chainCts: chainCts,
+		stakers:  stakers,
+		rand:     rand.New(rand.NewSource(seed)),
+		blocks: []simBlock{{
+			ID:          chainCts.GenesisBlockID(),
+			Timestamp:   chainCts.GenesisTimestamp,
+			Created:     chainCts.GenesisTimestamp,
+			ChildTarget: chainCts.RootTarget(),
+			Creator:     -1,
+		}},
+	}
+}
+
+// Run simulates the creation of the given amount of blocks.
+func (s *simulation) Run(blocks types.BlockHeight) error {
+	for height := types.BlockHeight(1); height <= blocks; height++ {
+		if err := s.createBlock(); err != nil {
+			return fmt.Errorf("failed to create block %d: %v", height, err)
+		}
+	}
+	return nil
+}
+
+// candidate is a block a staker is able to create.
+type candidate struct {
+	staker    int
+	timestamp types.Timestamp
+}
+
+// createBlock advances the fake clock second by second, until one of the online
+// stakers solves the next block, which is added to the chain. If multiple stakers
+// solve a block in the same second, a random one of them wins the race.
+func (s *simulation) createBlock() error {
+	parent := s.blocks[len(s.blocks)-1]
+	height := types.BlockHeight(len(s.blocks))
+	stakemodifier := consensus.StakeModifier(s.chainCts, height, s.blockIDs(height))
+	minTimestamp := s.minimumValidChildTimestamp()
+
+	online := make([]bool, len(s.stakers))
+	for i, st := range s.stakers {
+		online[i] = s.rand.Float64() < st.Uptime
+	}
+
+	start := parent.Created
+	limit := start + types.Timestamp(maxIdleBlocks*s.chainCts.BlockFrequency) + types.Timestamp(s.chainCts.BlockStakeAging)
+	for now := start; now < limit; now++ {
+		var candidates []candidate
+		for i, st := range s.stakers {
+			if !online[i] {
+				continue
+			}
+			// honest stakers only try the current time, while adversarial stakers try every
+			// timestamp accepted by consensus, which only has to be checked once
+			from, to := now, now
+			if st.Behaviour == adversarial {
+				from, to = now+s.chainCts.FutureThreshold, now+s.chainCts.FutureThreshold
+				if now == start {
+					from = minTimestamp
+				}
+			}
+			if from < minTimestamp {
+				from = minTimestamp
+			}
+			if from < st.eligible {
+				from = st.eligible
+			}
+			for timestamp := from; timestamp <= to; timestamp++ {
+				if blockcreator.SolvesTarget(stakemodifier, st.output, st.Stakes, timestamp, parent.ChildTarget) {
+					candidates = append(candidates, candidate{staker: i, timestamp: timestamp})
+					break
+				}
+			}
+		}
+		if len(candidates) > 0 {
+			s.addBlock(candidates[s.rand.Intn(len(candidates))], now)
+			return nil
+		}
+	}
+	return errNoBlockCreated
+}
+
+// addBlock adds the block created by the given candidate at the given time.
+func (s *simulation) addBlock(c candidate, now types.Timestamp) {
+	parent := s.blocks[len(s.blocks)-1]
+	height := types.BlockHeight(len(s.blocks))
+	st := s.stakers[c.staker]
+	b := simBlock{
+		ID:        types.BlockID(crypto.HashAll(parent.ID, c.timestamp, st.output)),
+		Timestamp: c.timestamp,
+		Created:   now,
+		Creator:   c.staker,
+	}
+
+	// the block stake output is respent in the block, and can be used again right away
+	st.output = types.BlockStakeOutputIndexes{BlockHeight: height}
+	st.eligible = 0
+	st.blocks++
+
+	b.ChildTarget = parent.ChildTarget
+	if consensus.IsTargetAdjustmentHeight(s.chainCts, height) {
+		windowSize := s.chainCts.TargetWindow
+		if windowSize > height {
+			windowSize = height
+		}
+		base := consensus.TargetAdjustmentBase(s.chainCts, b.Timestamp-s.blocks[height-windowSize].Timestamp, windowSize)
+		b.ChildTarget = consensus.AdjustTarget(s.chainCts, parent.ChildTarget, base)
+	}
+	s.blocks = append(s.blocks, b)
+}
+
+// blockIDs returns the IDs of the blocks used in the stake modifier for the given height,
+// starting with the block at height - StakeModifierDelay and going back towards genesis.
+func (s *simulation) blockIDs(height types.BlockHeight) func() types.BlockID {
+	h := int64(height) - int64(s.chainCts.StakeModifierDelay)
+	return func() types.BlockID {
+		id := s.blocks[h].ID
+		h--
+		return id
+	}
+}
+
+// minimumValidChildTimestamp returns the earliest timestamp the next block can have,
+// being the median timestamp of the last MedianTimestampWindow blocks.
+func (s *simulation) minimumValidChildTimestamp() types.Timestamp {
+	windowTimes := make(types.TimestampSlice, s.chainCts.MedianTimestampWindow)
+	for i := range windowTimes {
+		// use the genesis block timestamp for all blocks before genesis
+		height := len(s.blocks) - 1 - i
+		if height < 0 {
+			height = 0
+		}
+		windowTimes[i] = s.blocks[height].Timestamp
+	}
+	sort.Sort(windowTimes)
+	return windowTimes[len(windowTimes)/2]
+}
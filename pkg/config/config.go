@@ -59,6 +59,9 @@ func GetStandardnetGenesis() types.ChainConstants {
 	// Payouts take roughly 1 day to mature.
 	cfg.MaturityDelay = 720
 
+	// Blocks buried a day deep are final, and are never reverted.
+	cfg.MaxReorgDepth = 720
+
 	// The genesis timestamp
 	cfg.GenesisTimestamp = types.Timestamp(1522501000) // Human time 03/31/2018 @ 1:03pm (UTC)
 
@@ -76,9 +79,21 @@ func GetStandardnetGenesis() types.ChainConstants {
 	// Blockstake can be used roughly 1 day after receiving
 	cfg.BlockStakeAging = 1 << 17 // 2^16s < 1 day < 2^17s
 
+	// Blockstake can be transferred as soon as it is received,
+	// enabling a lockup on a running network requires a hard fork
+	cfg.BlockStakeLockup = 0
+
+	// Block stake delegation is a hard fork,
+	// it stays disabled until an activation height is scheduled
+	cfg.BlockStakeDelegationHeight = types.NoActivationHeight
+
 	// Receive 1 coins when you create a block
 	cfg.BlockCreatorFee = cfg.CurrencyUnits.OneCoin.Mul64(1)
 
+	// The block creator fee is constant,
+	// adding a block reward schedule on a running network requires a hard fork
+	cfg.BlockRewardSchedule = nil
+
 	// Use 0.1 coins as minimum transaction fee
 	cfg.MinimumTransactionFee = cfg.CurrencyUnits.OneCoin.Div64(10)
 
@@ -181,6 +196,9 @@ func GetTestnetGenesis() types.ChainConstants {
 	// Payouts take rougly 1 day to mature.
 	cfg.MaturityDelay = 720
 
+	// Blocks buried a day deep are final, and are never reverted.
+	cfg.MaxReorgDepth = 720
+
 	// The genesis timestamp is set to February 21st, 2018
 	cfg.GenesisTimestamp = types.Timestamp(1519200000) // February 21st, 2018 @ 8:00am UTC.
 
@@ -198,9 +216,21 @@ func GetTestnetGenesis() types.ChainConstants {
 	// Blockstake can be used roughly 1 minute after receiving
 	cfg.BlockStakeAging = uint64(1 << 6)
 
+	// Blockstake can be transferred as soon as it is received,
+	// enabling a lockup on a running network requires a hard fork
+	cfg.BlockStakeLockup = 0
+
+	// Block stake delegation is a hard fork,
+	// it stays disabled until an activation height is scheduled
+	cfg.BlockStakeDelegationHeight = types.NoActivationHeight
+
 	// Receive 10 coins when you create a block
 	cfg.BlockCreatorFee = cfg.CurrencyUnits.OneCoin.Mul64(10)
 
+	// The block creator fee is constant,
+	// adding a block reward schedule on a running network requires a hard fork
+	cfg.BlockRewardSchedule = nil
+
 	// Use 0.1 coins as minimum transaction fee
 	cfg.MinimumTransactionFee = cfg.CurrencyUnits.OneCoin.Div64(10)
 
@@ -246,6 +276,9 @@ func GetDevnetGenesis() types.ChainConstants {
 	// 120 seconds before a delayed output matters
 	// as it's expressed in units of blocks
 	cfg.MaturityDelay = 10
+
+	// Blocks buried 50 blocks deep are final, and are never reverted.
+	cfg.MaxReorgDepth = 50
 	cfg.MedianTimestampWindow = 11
 
 	// The genesis timestamp is set to February 21st, 2018
@@ -266,9 +299,25 @@ func GetDevnetGenesis() types.ChainConstants {
 	// Blockstake can be used roughly 1 minute after receiving
 	cfg.BlockStakeAging = uint64(1 << 6)
 
+	// Blockstake can only be transferred 10 blocks after receiving,
+	// respending it to the same address is always allowed
+	cfg.BlockStakeLockup = 10
+
+	// Block stakes can be delegated from the genesis block onwards
+	cfg.BlockStakeDelegationHeight = 0
+
 	// Receive 10 coins when you create a block
 	cfg.BlockCreatorFee = cfg.CurrencyUnits.OneCoin.Mul64(10)
 
+	// Starting at block 1000, the block creator fee halves every 1000 blocks
+	cfg.BlockRewardSchedule = types.BlockRewardSchedule{
+		{
+			Height:          1000,
+			Fee:             cfg.CurrencyUnits.OneCoin.Mul64(10),
+			HalvingInterval: 1000,
+		},
+	}
+
 	// Use 0.1 coins as minimum transaction fee
 	cfg.MinimumTransactionFee = cfg.CurrencyUnits.OneCoin.Mul64(1)
 